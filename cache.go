@@ -0,0 +1,55 @@
+package cleanenv
+
+import (
+	"sync"
+	"time"
+)
+
+// SecretCache memoizes resolved secret values by reference so repeated
+// lookups of the same secret, whether within one LoadConfigFromSecrets
+// call or across many, avoid redundant round-trips to the provider.
+type SecretCache interface {
+	// Get returns the cached value for ref, if present and not expired.
+	Get(ref string) (string, bool)
+	// Set stores value for ref.
+	Set(ref string, value string)
+}
+
+// NewSecretCache returns the default SecretCache: a sync.Map-backed store
+// where entries expire ttl after being set. A ttl of zero means entries
+// never expire.
+func NewSecretCache(ttl time.Duration) SecretCache {
+	return &memCache{ttl: ttl}
+}
+
+type memCacheEntry struct {
+	value     string
+	expiresAt time.Time // zero means "never"
+}
+
+type memCache struct {
+	ttl time.Duration
+	m   sync.Map // string -> memCacheEntry
+}
+
+func (c *memCache) Get(ref string) (string, bool) {
+	raw, ok := c.m.Load(ref)
+	if !ok {
+		return "", false
+	}
+
+	entry := raw.(memCacheEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.m.Delete(ref)
+		return "", false
+	}
+	return entry.value, true
+}
+
+func (c *memCache) Set(ref string, value string) {
+	entry := memCacheEntry{value: value}
+	if c.ttl > 0 {
+		entry.expiresAt = time.Now().Add(c.ttl)
+	}
+	c.m.Store(ref, entry)
+}
@@ -0,0 +1,46 @@
+package cleanenv
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSecretCacheGetSet(t *testing.T) {
+	cache := NewSecretCache(0)
+
+	if _, ok := cache.Get("ref"); ok {
+		t.Fatalf("Get on empty cache: got a hit, want a miss")
+	}
+
+	cache.Set("ref", "value")
+	value, ok := cache.Get("ref")
+	if !ok || value != "value" {
+		t.Fatalf("Get(%q) = (%q, %v), want (\"value\", true)", "ref", value, ok)
+	}
+}
+
+func TestSecretCacheZeroTTLNeverExpires(t *testing.T) {
+	cache := NewSecretCache(0)
+	cache.Set("ref", "value")
+
+	time.Sleep(10 * time.Millisecond)
+
+	if _, ok := cache.Get("ref"); !ok {
+		t.Fatalf("Get after sleep with zero TTL: got a miss, want a hit")
+	}
+}
+
+func TestSecretCacheEntryExpires(t *testing.T) {
+	cache := NewSecretCache(20 * time.Millisecond)
+	cache.Set("ref", "value")
+
+	if value, ok := cache.Get("ref"); !ok || value != "value" {
+		t.Fatalf("Get immediately after Set = (%q, %v), want (\"value\", true)", value, ok)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+
+	if _, ok := cache.Get("ref"); ok {
+		t.Fatalf("Get after TTL elapsed: got a hit, want a miss")
+	}
+}
@@ -0,0 +1,153 @@
+package cleanenv
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/compute/metadata"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// gcpSecretVersionLatest is the version suffix used when a short-form
+// gcp_secret tag omits one.
+const gcpSecretVersionLatest = "latest"
+
+// gcpProjectOverrideKey threads a WithGCPProject override from
+// LoadConfigFromSecrets through to the "gcp" scheme's Registry dispatch,
+// which only ever sees a context.Context and a reference string.
+type gcpProjectOverrideKey struct{}
+
+// withGCPProjectOverride attaches project (possibly empty) to ctx so the
+// "gcp" SecretProvider registered in init() can see a per-call
+// WithGCPProject override.
+func withGCPProjectOverride(ctx context.Context, project string) context.Context {
+	if project == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, gcpProjectOverrideKey{}, project)
+}
+
+// gcpProjectOverrideFromContext returns the project attached by
+// withGCPProjectOverride, or "" if none was set.
+func gcpProjectOverrideFromContext(ctx context.Context) string {
+	project, _ := ctx.Value(gcpProjectOverrideKey{}).(string)
+	return project
+}
+
+// resolveGCPSecretName expands a gcp_secret tag value into a fully
+// qualified "projects/{id}/secrets/{name}/versions/{version}" resource
+// name. Values already in that form are returned unchanged. Short forms
+// are supported:
+//
+//	"my-db-password"            -> projects/{id}/secrets/my-db-password/versions/latest
+//	"my-db-password/versions/3" -> projects/{id}/secrets/my-db-password/versions/3
+//
+// projectOverride, when non-empty, takes precedence over environment
+// variables and the GCE metadata server when resolving {id}.
+func resolveGCPSecretName(ctx context.Context, tagValue, projectOverride string) (string, error) {
+	if strings.HasPrefix(tagValue, "projects/") {
+		return tagValue, nil
+	}
+
+	name, version, ok := strings.Cut(tagValue, "/versions/")
+	if !ok {
+		name, version = tagValue, gcpSecretVersionLatest
+	}
+
+	project, err := resolveGCPProjectID(ctx, projectOverride)
+	if err != nil {
+		return "", fmt.Errorf("resolving GCP project for secret %q: %w", tagValue, err)
+	}
+
+	return fmt.Sprintf("projects/%s/secrets/%s/versions/%s", project, name, version), nil
+}
+
+// resolveGCPProjectID determines the GCP project to use for short-form
+// gcp_secret references, in order of precedence:
+//  1. override (set via WithGCPProject)
+//  2. the GOOGLE_CLOUD_PROJECT environment variable
+//  3. the GCP_PROJECT environment variable
+//  4. the GCE metadata server
+func resolveGCPProjectID(ctx context.Context, override string) (string, error) {
+	if override != "" {
+		return override, nil
+	}
+	if id := os.Getenv("GOOGLE_CLOUD_PROJECT"); id != "" {
+		return id, nil
+	}
+	if id := os.Getenv("GCP_PROJECT"); id != "" {
+		return id, nil
+	}
+
+	id, err := metadata.ProjectIDWithContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("no project configured (set WithGCPProject, GOOGLE_CLOUD_PROJECT/GCP_PROJECT, or run on GCE) and metadata server lookup failed: %w", err)
+	}
+	return id, nil
+}
+
+// gcpLabelFilter builds a Secret Manager list filter expression from a
+// "key=value,key=value" label selector, e.g. "env=prod,role=db-password"
+// becomes `labels.env=prod AND labels.role=db-password`.
+func gcpLabelFilter(selector string) (string, error) {
+	pairs := strings.Split(selector, ",")
+	clauses := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || key == "" || value == "" {
+			return "", fmt.Errorf("invalid label selector %q: expected \"key=value\" pairs", selector)
+		}
+		clauses = append(clauses, fmt.Sprintf("labels.%s=%s", key, value))
+	}
+	return strings.Join(clauses, " AND "), nil
+}
+
+// loadGCPSecretByLabel finds the single secret matching selector (a
+// "key=value,key=value" label selector) under project, and returns the
+// payload of its latest version. It returns an error naming the ambiguous
+// candidates if more than one secret matches.
+func loadGCPSecretByLabel(ctx context.Context, selector, projectOverride string) (string, error) {
+	project, err := resolveGCPProjectID(ctx, projectOverride)
+	if err != nil {
+		return "", fmt.Errorf("resolving GCP project for label selector %q: %w", selector, err)
+	}
+
+	filter, err := gcpLabelFilter(selector)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := getGCPClient(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to create GCP secret manager client: %w", err)
+	}
+
+	it := client.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{
+		Parent: "projects/" + project,
+		Filter: filter,
+	})
+
+	var matches []string
+	for {
+		secret, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("listing GCP secrets matching %q: %w", selector, err)
+		}
+		matches = append(matches, secret.Name)
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no GCP secret matches label selector %q", selector)
+	case 1:
+		return loadGCPSecret(ctx, matches[0]+"/versions/"+gcpSecretVersionLatest)
+	default:
+		return "", fmt.Errorf("label selector %q matches %d secrets, expected exactly 1: %s", selector, len(matches), strings.Join(matches, ", "))
+	}
+}
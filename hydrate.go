@@ -0,0 +1,164 @@
+package cleanenv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// secretValuePrefix marks a string field as holding a secret reference
+// rather than a literal value. Hydrate resolves any field whose value has
+// this prefix through the supplied SecretProvider.
+const secretValuePrefix = "$SECRET:"
+
+// Hydrate recursively walks cfgPtr (a pointer to a struct) and replaces
+// every string field whose value has the "$SECRET:{ref}" prefix with the
+// value fetched from provider for {ref}. Unlike LoadConfigFromSecrets,
+// which only looks at struct tags, Hydrate lets secrets be declared as
+// plain values in a config file already loaded into cfgPtr, e.g. a YAML
+// field `db_password: "$SECRET:projects/x/secrets/db/versions/latest"`.
+//
+// Hydrate walks nested structs, pointers, slices, maps, and interface
+// values, follows cycles safely, fetches each unique reference at most
+// once, and fetches all unique references concurrently. If multiple
+// references fail to resolve, their errors are joined together rather
+// than the first one short-circuiting the rest.
+func Hydrate(ctx context.Context, provider SecretProvider, cfgPtr interface{}) error {
+	val := reflect.ValueOf(cfgPtr)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("cleanenv: Hydrate requires a non-nil pointer, got %T", cfgPtr)
+	}
+
+	c := &hydrator{
+		visited: make(map[uintptr]bool),
+		refs:    make(map[string][]reflect.Value),
+	}
+	c.collect(val.Elem())
+
+	if len(c.refs) == 0 {
+		return nil
+	}
+
+	resolved := make(map[string]string, len(c.refs))
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+	)
+	for ref := range c.refs {
+		ref := ref
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := provider.Fetch(ctx, ref)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("cleanenv: fetching secret %q: %w", ref, err))
+				return
+			}
+			resolved[ref] = value
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	for ref, targets := range c.refs {
+		value := resolved[ref]
+		for _, target := range targets {
+			target.SetString(value)
+		}
+	}
+
+	// Map entries and interface values are never addressable, so fields
+	// hydrated inside one were written into a temporary copy; write each
+	// temporary back into its container now that its contents are fully
+	// resolved.
+	for _, writeback := range c.writebacks {
+		writeback()
+	}
+	return nil
+}
+
+// hydrator collects addressable string fields that need hydrating.
+type hydrator struct {
+	visited    map[uintptr]bool
+	refs       map[string][]reflect.Value // secret ref -> settable string fields referencing it
+	writebacks []func()
+}
+
+func (h *hydrator) collect(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		ptr := v.Pointer()
+		if h.visited[ptr] {
+			return
+		}
+		h.visited[ptr] = true
+		h.collect(v.Elem())
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		// An interface's dynamic value is never addressable, even when the
+		// interface itself is (e.g. a map[string]interface{} entry holding
+		// a bare "$SECRET:..." string, the shape produced by generic
+		// YAML/JSON unmarshaling). Materialize it into an addressable
+		// temporary, collect into that, and write it back into the
+		// interface once resolved.
+		inner := v.Elem()
+		tmp := reflect.New(inner.Type()).Elem()
+		tmp.Set(inner)
+		h.collect(tmp)
+
+		if v.CanSet() {
+			vv, tt := v, tmp
+			h.writebacks = append(h.writebacks, func() { vv.Set(tt) })
+		}
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			if !field.CanInterface() {
+				continue // unexported field
+			}
+			h.collect(field)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			h.collect(v.Index(i))
+		}
+
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			entry := v.MapIndex(key)
+			// Map values aren't addressable; materialize into an
+			// addressable temporary, collect into it, and defer writing
+			// it back until the temporary's fields are fully resolved.
+			tmp := reflect.New(entry.Type()).Elem()
+			tmp.Set(entry)
+			h.collect(tmp)
+
+			m, k, t := v, key, tmp
+			h.writebacks = append(h.writebacks, func() { m.SetMapIndex(k, t) })
+		}
+
+	case reflect.String:
+		if !v.CanSet() {
+			return
+		}
+		if ref, ok := strings.CutPrefix(v.String(), secretValuePrefix); ok {
+			h.refs[ref] = append(h.refs[ref], v)
+		}
+	}
+}
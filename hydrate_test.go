@@ -0,0 +1,167 @@
+package cleanenv
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// mapProvider resolves references from a fixed map and counts fetches per
+// ref so tests can assert on dedup behavior.
+type mapProvider struct {
+	values map[string]string
+	calls  map[string]*int32
+}
+
+func newMapProvider(values map[string]string) *mapProvider {
+	calls := make(map[string]*int32, len(values))
+	for ref := range values {
+		calls[ref] = new(int32)
+	}
+	return &mapProvider{values: values, calls: calls}
+}
+
+func (p *mapProvider) Fetch(_ context.Context, ref string) (string, error) {
+	if counter, ok := p.calls[ref]; ok {
+		atomic.AddInt32(counter, 1)
+	}
+	value, ok := p.values[ref]
+	if !ok {
+		return "", fmt.Errorf("no such ref %q", ref)
+	}
+	return value, nil
+}
+
+type hydrateTarget struct {
+	Plain    string
+	Password string
+	Nested   *hydrateTarget
+	Tags     []string
+	Labels   map[string]string
+}
+
+func TestHydrateNestedStructsPointersSlicesMaps(t *testing.T) {
+	provider := newMapProvider(map[string]string{
+		"db/password":  "s3cr3t",
+		"api/token":    "t0k3n",
+		"cache/secret": "c4ch3",
+	})
+
+	cfg := &hydrateTarget{
+		Plain:    "unchanged",
+		Password: "$SECRET:db/password",
+		Nested: &hydrateTarget{
+			Password: "$SECRET:api/token",
+		},
+		Tags: []string{"$SECRET:db/password", "literal"},
+		Labels: map[string]string{
+			"cache": "$SECRET:cache/secret",
+			"kept":  "literal",
+		},
+	}
+
+	if err := Hydrate(context.Background(), provider, cfg); err != nil {
+		t.Fatalf("Hydrate: %v", err)
+	}
+
+	if cfg.Plain != "unchanged" {
+		t.Errorf("Plain = %q, want unchanged", cfg.Plain)
+	}
+	if cfg.Password != "s3cr3t" {
+		t.Errorf("Password = %q, want s3cr3t", cfg.Password)
+	}
+	if cfg.Nested.Password != "t0k3n" {
+		t.Errorf("Nested.Password = %q, want t0k3n", cfg.Nested.Password)
+	}
+	if cfg.Tags[0] != "s3cr3t" || cfg.Tags[1] != "literal" {
+		t.Errorf("Tags = %v, want [s3cr3t literal]", cfg.Tags)
+	}
+	if cfg.Labels["cache"] != "c4ch3" || cfg.Labels["kept"] != "literal" {
+		t.Errorf("Labels = %v, want map[cache:c4ch3 kept:literal]", cfg.Labels)
+	}
+
+	// "db/password" is referenced twice (Password and Tags[0]) and must
+	// only be fetched once.
+	if got := atomic.LoadInt32(provider.calls["db/password"]); got != 1 {
+		t.Errorf("fetches of db/password = %d, want 1 (dedup failed)", got)
+	}
+}
+
+func TestHydrateResolvesInterfaceHeldSecrets(t *testing.T) {
+	provider := newMapProvider(map[string]string{
+		"db/password": "s3cr3t",
+		"api/token":   "t0k3n",
+	})
+
+	// The shape generic YAML/JSON unmarshaling produces: secrets held as
+	// bare strings and nested structs behind interface{}, not string or
+	// pointer fields.
+	cfg := struct {
+		Settings map[string]interface{}
+		Any      interface{}
+	}{
+		Settings: map[string]interface{}{
+			"db_password": "$SECRET:db/password",
+			"kept":        "literal",
+		},
+		Any: hydrateTarget{
+			Password: "$SECRET:api/token",
+		},
+	}
+
+	if err := Hydrate(context.Background(), provider, &cfg); err != nil {
+		t.Fatalf("Hydrate: %v", err)
+	}
+
+	if got := cfg.Settings["db_password"]; got != "s3cr3t" {
+		t.Errorf("Settings[db_password] = %v, want s3cr3t", got)
+	}
+	if got := cfg.Settings["kept"]; got != "literal" {
+		t.Errorf("Settings[kept] = %v, want literal", got)
+	}
+	if got, ok := cfg.Any.(hydrateTarget); !ok || got.Password != "t0k3n" {
+		t.Errorf("Any = %#v, want hydrateTarget with Password t0k3n", cfg.Any)
+	}
+}
+
+func TestHydrateFollowsCycles(t *testing.T) {
+	a := &hydrateTarget{Password: "$SECRET:db/password"}
+	a.Nested = a // self-cycle
+
+	provider := newMapProvider(map[string]string{"db/password": "s3cr3t"})
+
+	if err := Hydrate(context.Background(), provider, a); err != nil {
+		t.Fatalf("Hydrate on cyclic struct: %v", err)
+	}
+	if a.Password != "s3cr3t" {
+		t.Errorf("Password = %q, want s3cr3t", a.Password)
+	}
+}
+
+// erroringProvider fails every fetch so tests can assert errors from
+// multiple concurrent fetches are all preserved.
+type erroringProvider struct{}
+
+func (erroringProvider) Fetch(_ context.Context, ref string) (string, error) {
+	return "", fmt.Errorf("boom: %s", ref)
+}
+
+func TestHydrateJoinsAllFetchErrors(t *testing.T) {
+	cfg := &hydrateTarget{
+		Password: "$SECRET:one",
+		Nested:   &hydrateTarget{Password: "$SECRET:two"},
+	}
+
+	err := Hydrate(context.Background(), erroringProvider{}, cfg)
+	if err == nil {
+		t.Fatal("Hydrate: got nil error, want an aggregated error")
+	}
+
+	for _, want := range []string{"one", "two"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("joined error %q missing failure for ref %q", err, want)
+		}
+	}
+}
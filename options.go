@@ -0,0 +1,55 @@
+package cleanenv
+
+// defaultConcurrency is the number of secrets LoadConfigFromSecrets will
+// fetch in flight at once when no WithConcurrency option is given.
+const defaultConcurrency = 8
+
+// loadOptions holds the configuration built up by a LoadConfigFromSecrets
+// call's Option arguments.
+type loadOptions struct {
+	concurrency int
+	cache       SecretCache
+	gcpProject  string
+}
+
+func newLoadOptions(opts []Option) *loadOptions {
+	o := &loadOptions{concurrency: defaultConcurrency}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Option configures a LoadConfigFromSecrets call.
+type Option func(*loadOptions)
+
+// WithConcurrency bounds the number of secrets fetched in flight at once.
+// The default is 8. n <= 0 is treated as 1.
+func WithConcurrency(n int) Option {
+	return func(o *loadOptions) {
+		if n <= 0 {
+			n = 1
+		}
+		o.concurrency = n
+	}
+}
+
+// WithGCPProject sets the GCP project ID used to expand short-form
+// gcp_secret tags (e.g. `gcp_secret:"my-db-password"`). When unset,
+// LoadConfigFromSecrets falls back to the GOOGLE_CLOUD_PROJECT/GCP_PROJECT
+// environment variables and finally the GCE metadata server.
+func WithGCPProject(id string) Option {
+	return func(o *loadOptions) {
+		o.gcpProject = id
+	}
+}
+
+// WithCache makes LoadConfigFromSecrets consult cache before fetching a
+// secret and populate it after a successful fetch, letting long-lived
+// processes reuse resolved values across multiple calls. See NewSecretCache
+// for the default TTL-based implementation.
+func WithCache(cache SecretCache) Option {
+	return func(o *loadOptions) {
+		o.cache = cache
+	}
+}
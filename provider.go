@@ -0,0 +1,128 @@
+package cleanenv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// SecretProvider resolves a reference string into a secret value. Providers
+// are registered against a scheme prefix (e.g. "vault", "aws-ssm") and
+// looked up through the Registry by callers of LoadConfigFromSecrets or
+// Hydrate.
+type SecretProvider interface {
+	// Fetch resolves ref (the reference with its scheme prefix stripped)
+	// to the secret value it names.
+	Fetch(ctx context.Context, ref string) (string, error)
+}
+
+// SecretProviderFunc adapts a plain function to the SecretProvider
+// interface.
+type SecretProviderFunc func(ctx context.Context, ref string) (string, error)
+
+// Fetch calls f(ctx, ref).
+func (f SecretProviderFunc) Fetch(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+// Registry maps a scheme prefix to the SecretProvider that serves it.
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]SecretProvider
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{providers: make(map[string]SecretProvider)}
+}
+
+// Register associates scheme with provider, overwriting any previous
+// registration for that scheme.
+func (r *Registry) Register(scheme string, provider SecretProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[scheme] = provider
+}
+
+// Lookup returns the provider registered for scheme, if any.
+func (r *Registry) Lookup(scheme string) (SecretProvider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.providers[scheme]
+	return p, ok
+}
+
+// Fetch resolves a full "<scheme>:<path>[#<subfield>]" reference by
+// dispatching to the provider registered for its scheme.
+func (r *Registry) Fetch(ctx context.Context, reference string) (string, error) {
+	scheme, path, subfield, err := ParseRef(reference)
+	if err != nil {
+		return "", err
+	}
+
+	provider, ok := r.Lookup(scheme)
+	if !ok {
+		return "", fmt.Errorf("cleanenv: no secret provider registered for scheme %q (reference %q)", scheme, reference)
+	}
+
+	value, err := provider.Fetch(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("cleanenv: fetching %q: %w", reference, err)
+	}
+
+	if subfield == "" {
+		return value, nil
+	}
+	return extractSubfield(value, subfield)
+}
+
+// defaultRegistry is the process-wide Registry used by LoadConfigFromSecrets
+// and Hydrate unless a caller supplies their own.
+var defaultRegistry = NewRegistry()
+
+// RegisterProvider registers provider under scheme in the default registry,
+// making it available to LoadConfigFromSecrets and Hydrate via the
+// TagSecret tag, e.g. `secret:"<scheme>:<ref>"`.
+func RegisterProvider(scheme string, provider SecretProvider) {
+	defaultRegistry.Register(scheme, provider)
+}
+
+// ParseRef splits a secret reference of the form "<scheme>:<path>[#<subfield>]"
+// into its components. The subfield, when present, selects a JSON field
+// from a secret whose value is a JSON object.
+func ParseRef(reference string) (scheme, path, subfield string, err error) {
+	schemeAndRest := strings.SplitN(reference, ":", 2)
+	if len(schemeAndRest) != 2 {
+		return "", "", "", fmt.Errorf("cleanenv: invalid secret reference %q: missing scheme prefix", reference)
+	}
+	scheme = schemeAndRest[0]
+
+	rest := schemeAndRest[1]
+	if idx := strings.LastIndex(rest, "#"); idx >= 0 {
+		return scheme, rest[:idx], rest[idx+1:], nil
+	}
+	return scheme, rest, "", nil
+}
+
+// extractSubfield treats value as a JSON object and returns the string
+// value of its subfield key.
+func extractSubfield(value, subfield string) (string, error) {
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &obj); err != nil {
+		return "", fmt.Errorf("cleanenv: secret value is not a JSON object, cannot select subfield %q: %w", subfield, err)
+	}
+
+	raw, ok := obj[subfield]
+	if !ok {
+		return "", fmt.Errorf("cleanenv: secret value has no subfield %q", subfield)
+	}
+
+	str, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("cleanenv: subfield %q is not a string (got %T)", subfield, raw)
+	}
+	return str, nil
+}
@@ -0,0 +1,47 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// File is a cleanenv.SecretProvider that reads secret values from local
+// files, e.g. Kubernetes/Docker secret mounts. References are paths
+// relative to Root (absolute references are rejected unless Root is empty).
+type File struct {
+	// Root is the directory references are resolved against. If empty,
+	// references are treated as absolute or cwd-relative paths.
+	Root string
+}
+
+// NewFile returns a File provider rooted at root.
+func NewFile(root string) *File {
+	return &File{Root: root}
+}
+
+// Fetch reads the file named by ref and returns its contents with
+// surrounding whitespace trimmed (most secret-mount tooling appends a
+// trailing newline).
+func (f *File) Fetch(_ context.Context, ref string) (string, error) {
+	path := ref
+	if f.Root != "" {
+		if filepath.IsAbs(ref) {
+			return "", fmt.Errorf("providers: secret ref %q must be relative to Root %q", ref, f.Root)
+		}
+
+		path = filepath.Join(f.Root, ref)
+		root := filepath.Clean(f.Root)
+		if path != root && !strings.HasPrefix(path, root+string(filepath.Separator)) {
+			return "", fmt.Errorf("providers: secret ref %q escapes Root %q", ref, f.Root)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("providers: reading secret file %q: %w", path, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,47 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// SSM is a cleanenv.SecretProvider backed by AWS Systems Manager Parameter
+// Store. References are parameter names, e.g. "/myapp/db/password".
+type SSM struct {
+	client *ssm.Client
+}
+
+// NewSSM returns an SSM provider using client.
+func NewSSM(client *ssm.Client) *SSM {
+	return &SSM{client: client}
+}
+
+// NewSSMFromEnv builds an SSM provider using the default AWS SDK
+// credential chain and region resolution.
+func NewSSMFromEnv(ctx context.Context) (*SSM, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("providers: loading AWS SDK config: %w", err)
+	}
+	return NewSSM(ssm.NewFromConfig(cfg)), nil
+}
+
+// Fetch retrieves and decrypts the named SSM parameter.
+func (s *SSM) Fetch(ctx context.Context, name string) (string, error) {
+	out, err := s.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           &name,
+		WithDecryption: boolPtr(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("providers: ssm get-parameter %q: %w", name, err)
+	}
+	if out.Parameter == nil || out.Parameter.Value == nil {
+		return "", fmt.Errorf("providers: ssm parameter %q has no value", name)
+	}
+	return *out.Parameter.Value, nil
+}
+
+func boolPtr(b bool) *bool { return &b }
@@ -0,0 +1,82 @@
+// Package providers contains built-in cleanenv.SecretProvider
+// implementations for popular secret stores. Importing this package does
+// not register anything automatically; callers register the providers they
+// need with cleanenv.RegisterProvider.
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// Vault is a cleanenv.SecretProvider backed by a HashiCorp Vault (or
+// OpenBao, see NewOpenBao) KV secrets engine. References are the mount path
+// of the secret, e.g. "secret/data/db" for KV v2 or "secret/db" for KV v1.
+type Vault struct {
+	client *vaultapi.Client
+	kvV2   bool
+}
+
+// NewVault returns a Vault provider using client. Set kvV2 to true when the
+// target mount uses the KV version 2 engine (the default for new Vault
+// mounts), which nests secret data under a "data" key.
+func NewVault(client *vaultapi.Client, kvV2 bool) *Vault {
+	return &Vault{client: client, kvV2: kvV2}
+}
+
+// NewVaultFromEnv builds a Vault provider from the standard VAULT_ADDR /
+// VAULT_TOKEN environment variables, as understood by the Vault SDK.
+func NewVaultFromEnv(kvV2 bool) (*Vault, error) {
+	client, err := vaultapi.NewClient(vaultapi.DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("providers: creating vault client: %w", err)
+	}
+	return NewVault(client, kvV2), nil
+}
+
+// Fetch reads the secret at path (ref) from Vault. If the secret's data map
+// holds a single key, that key's value is returned directly; otherwise the
+// full data map is returned JSON-encoded so the caller can select a field
+// via the "#subfield" reference syntax (see cleanenv.ParseRef).
+func (v *Vault) Fetch(ctx context.Context, path string) (string, error) {
+	secret, err := v.client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("providers: vault read %q: %w", path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return "", fmt.Errorf("providers: vault secret %q not found", path)
+	}
+
+	data := secret.Data
+	if v.kvV2 {
+		nested, ok := secret.Data["data"].(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("providers: vault secret %q has no KV v2 \"data\" field", path)
+		}
+		data = nested
+	}
+
+	if len(data) == 1 {
+		for _, value := range data {
+			if s, ok := value.(string); ok {
+				return s, nil
+			}
+		}
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("providers: vault secret %q: encoding data as JSON: %w", path, err)
+	}
+	return string(encoded), nil
+}
+
+// NewOpenBao returns a provider for OpenBao, the Vault-API-compatible fork.
+// OpenBao speaks the same HTTP API as Vault, so this simply wraps Vault;
+// point client at an OPENBAO_ADDR-configured address.
+func NewOpenBao(client *vaultapi.Client, kvV2 bool) *Vault {
+	return NewVault(client, kvV2)
+}
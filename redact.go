@@ -0,0 +1,249 @@
+package cleanenv
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// redactedPlaceholder replaces every occurrence of a known secret value in
+// redacted output.
+const redactedPlaceholder = "[REDACTED]"
+
+// Redactor holds a set of secret values and scrubs them from text written
+// through the writers and slog handlers it wraps. A Redactor is safe for
+// concurrent use.
+type Redactor struct {
+	mu      sync.RWMutex
+	secrets []string // sorted longest-first so overlapping secrets redact greedily
+	maxLen  int
+}
+
+// NewRedactor returns an empty Redactor.
+func NewRedactor() *Redactor {
+	return &Redactor{}
+}
+
+// defaultRedactor is populated automatically by LoadConfigFromSecrets and
+// backs the package-level NewRedactingWriter/NewRedactingSlogHandler.
+var defaultRedactor = NewRedactor()
+
+// Add registers value so it is scrubbed from anything written through a
+// writer or slog handler wrapping this Redactor. Empty values and values
+// already registered are ignored.
+func (r *Redactor) Add(value string) {
+	if value == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.secrets {
+		if s == value {
+			return
+		}
+	}
+
+	// Keep secrets sorted longest-first so a secret that is a substring of
+	// another is never redacted before the longer one gets a chance to
+	// match. Build a fresh slice rather than mutating in place: redact()
+	// takes a snapshot of r.secrets under RLock and then ranges over it
+	// after releasing the lock, so mutating the existing backing array
+	// here would race with that read.
+	i := 0
+	for ; i < len(r.secrets); i++ {
+		if len(value) > len(r.secrets[i]) {
+			break
+		}
+	}
+	next := make([]string, len(r.secrets)+1)
+	copy(next, r.secrets[:i])
+	next[i] = value
+	copy(next[i+1:], r.secrets[i:])
+	r.secrets = next
+
+	if len(value) > r.maxLen {
+		r.maxLen = len(value)
+	}
+}
+
+func (r *Redactor) redact(b []byte) []byte {
+	r.mu.RLock()
+	secrets := r.secrets
+	r.mu.RUnlock()
+
+	for _, secret := range secrets {
+		b = bytes.ReplaceAll(b, []byte(secret), []byte(redactedPlaceholder))
+	}
+	return b
+}
+
+func (r *Redactor) holdBack() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.maxLen == 0 {
+		return 0
+	}
+	return r.maxLen - 1
+}
+
+// Wrap returns an io.Writer that scrubs every registered secret from bytes
+// before forwarding them to w. Secrets that straddle two Write calls are
+// still caught: up to maxLen(secret)-1 trailing bytes of each write are
+// buffered until enough of the next write arrives to rule out (or redact)
+// a split match.
+//
+// The returned writer holds a single pending-bytes buffer shared by every
+// Write call, so it must only be used as one logical, already-serialized
+// stream — e.g. wrap the io.Writer that sits behind a *log.Logger (which
+// serializes calls to Output itself) or os.Stderr used by a single
+// goroutine at a time. If multiple goroutines call Write on the same
+// wrapped writer with genuinely unrelated content, a secret held back
+// from one goroutine's write can be flushed alongside a later, unrelated
+// write from another goroutine, which both interleaves unrelated output
+// and can emit an incompletely-redacted fragment. Wrapping is safe from a
+// data-race standpoint either way (the shared buffer is mutex-guarded),
+// but only a single serialized stream gets the cross-call redaction
+// guarantee. For concurrent structured logging where records are handled
+// one at a time, prefer WrapSlog, which redacts whole records atomically
+// and never buffers across calls.
+func (r *Redactor) Wrap(w io.Writer) io.Writer {
+	return &redactingWriter{redactor: r, w: w}
+}
+
+type redactingWriter struct {
+	redactor *Redactor
+	w        io.Writer
+
+	// mu is held for the duration of each Write call, including the
+	// underlying write to w, so that concurrent Write calls are fully
+	// serialized and flush in the same order their callers acquired the
+	// lock. See the Wrap doc comment: this does not make unrelated
+	// concurrent writers share one logical stream, it only guarantees
+	// pending is never corrupted and flushes never reorder relative to
+	// lock acquisition.
+	mu      sync.Mutex
+	pending []byte
+}
+
+func (rw *redactingWriter) Write(p []byte) (int, error) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	combined := append(rw.pending, p...)
+	redacted := rw.redactor.redact(combined)
+
+	holdBack := rw.redactor.holdBack()
+	if holdBack >= len(redacted) {
+		rw.pending = append(rw.pending[:0], redacted...)
+		return len(p), nil
+	}
+
+	flush, pending := redacted[:len(redacted)-holdBack], redacted[len(redacted)-holdBack:]
+	rw.pending = append(rw.pending[:0], pending...)
+
+	if _, err := rw.w.Write(flush); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close flushes any buffered trailing bytes, redacting them as-is. Callers
+// that wrap a long-lived writer (e.g. os.Stderr) generally don't need to
+// call Close; it exists for writers whose lifetime ends with the process
+// still holding data in the buffer.
+func (rw *redactingWriter) Close() error {
+	rw.mu.Lock()
+	pending := rw.pending
+	rw.pending = nil
+	rw.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+	_, err := rw.w.Write(pending)
+	return err
+}
+
+// NewRedactingWriter wraps w so that any secret value resolved by
+// LoadConfigFromSecrets (or added manually via Redactor.Add) is replaced
+// with "[REDACTED]" before reaching w. This is the package-wide default
+// Redactor; use Redactor.Wrap directly to scope redaction to a custom set
+// of secrets instead.
+func NewRedactingWriter(w io.Writer) io.Writer {
+	return defaultRedactor.Wrap(w)
+}
+
+// WrapSlog returns an slog.Handler that scrubs registered secrets from
+// every attribute and message value h is asked to handle.
+func (r *Redactor) WrapSlog(h slog.Handler) slog.Handler {
+	return &redactingSlogHandler{redactor: r, h: h}
+}
+
+type redactingSlogHandler struct {
+	redactor *Redactor
+	h        slog.Handler
+}
+
+func (h *redactingSlogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.h.Enabled(ctx, level)
+}
+
+func (h *redactingSlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	record.Message = h.redactString(record.Message)
+
+	redacted := slog.Record{
+		Time:    record.Time,
+		Message: record.Message,
+		Level:   record.Level,
+		PC:      record.PC,
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(h.redactAttr(a))
+		return true
+	})
+	return h.h.Handle(ctx, redacted)
+}
+
+func (h *redactingSlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = h.redactAttr(a)
+	}
+	return &redactingSlogHandler{redactor: h.redactor, h: h.h.WithAttrs(redacted)}
+}
+
+func (h *redactingSlogHandler) WithGroup(name string) slog.Handler {
+	return &redactingSlogHandler{redactor: h.redactor, h: h.h.WithGroup(name)}
+}
+
+func (h *redactingSlogHandler) redactAttr(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(h.redactString(a.Value.String()))
+	case slog.KindGroup:
+		group := a.Value.Group()
+		redacted := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			redacted[i] = h.redactAttr(ga)
+		}
+		a.Value = slog.GroupValue(redacted...)
+	}
+	return a
+}
+
+func (h *redactingSlogHandler) redactString(s string) string {
+	return string(h.redactor.redact([]byte(s)))
+}
+
+// NewRedactingSlogHandler wraps h so that any secret value resolved by
+// LoadConfigFromSecrets (or added manually via Redactor.Add) is replaced
+// with "[REDACTED]" in logged messages and string attributes. Unlike
+// NewRedactingWriter, a slog handler sees whole records rather than a
+// byte stream, so there is no cross-call buffering to worry about.
+func NewRedactingSlogHandler(h slog.Handler) slog.Handler {
+	return defaultRedactor.WrapSlog(h)
+}
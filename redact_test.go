@@ -0,0 +1,169 @@
+package cleanenv
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+func TestRedactorRedactsRegisteredSecret(t *testing.T) {
+	r := NewRedactor()
+	r.Add("s3cr3t")
+
+	got := string(r.redact([]byte("password=s3cr3t end")))
+	want := "password=[REDACTED] end"
+	if got != want {
+		t.Errorf("redact = %q, want %q", got, want)
+	}
+}
+
+func TestRedactorLongestSecretWinsOnOverlap(t *testing.T) {
+	r := NewRedactor()
+	r.Add("secret")
+	r.Add("secretvalue")
+
+	got := string(r.redact([]byte("x=secretvalue")))
+	want := "x=[REDACTED]"
+	if got != want {
+		t.Errorf("redact = %q, want %q (longer secret should win, not leave a stray \"value\" suffix)", got, want)
+	}
+}
+
+func TestRedactorAddIgnoresEmptyAndDuplicates(t *testing.T) {
+	r := NewRedactor()
+	r.Add("")
+	r.Add("dup")
+	r.Add("dup")
+
+	if len(r.secrets) != 1 {
+		t.Fatalf("len(secrets) = %d, want 1", len(r.secrets))
+	}
+}
+
+func TestRedactingWriterAcrossSplitWrites(t *testing.T) {
+	r := NewRedactor()
+	r.Add("secretvalue")
+
+	var out bytes.Buffer
+	w := r.Wrap(&out)
+
+	// Split the secret across two Write calls.
+	if _, err := w.Write([]byte("token=secretv")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := w.Write([]byte("alue end")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if closer, ok := w.(interface{ Close() error }); ok {
+		if err := closer.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+	}
+
+	if got, want := out.String(), "token=[REDACTED] end"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+// TestRedactingWriterConcurrentWritesNoDataRace exercises Write and Add
+// from many goroutines to confirm the shared pending buffer never
+// corrupts under `go test -race`. It only asserts data-race safety, not
+// that concurrent unrelated writers redact correctly as one stream — see
+// TestRedactingWriterCrossGoroutineSplitCanLeak and the Wrap doc comment
+// for that limitation.
+func TestRedactingWriterConcurrentWritesNoDataRace(t *testing.T) {
+	r := NewRedactor()
+	r.Add("s3cr3t")
+
+	w := r.Wrap(&discard{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			w.Write([]byte("line with s3cr3t in it\n"))
+			r.Add("extra-secret") // exercises Add racing with Write
+			_ = n
+		}(i)
+	}
+	wg.Wait()
+}
+
+// TestRedactingWriterCrossGoroutineSplitCanLeak documents a known
+// limitation (see the Wrap doc comment): the pending-bytes buffer is
+// shared by every caller, so a secret split across two Write calls from
+// one logical stream can be corrupted by an unrelated write from another
+// goroutine landing in between. Wrap must only be used by a single
+// logical, already-serialized writer; this test pins down the current,
+// documented behavior rather than leaving the gap undiscovered.
+func TestRedactingWriterCrossGoroutineSplitCanLeak(t *testing.T) {
+	r := NewRedactor()
+	r.Add("secretvalue")
+
+	var out bytes.Buffer
+	w := r.Wrap(&out)
+
+	// The first half of a split secret, written by one logical stream...
+	if _, err := w.Write([]byte("token=secretv")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	// ...interleaved with a complete, unrelated write from another stream,
+	// long enough to push the first stream's held-back fragment past the
+	// holdback window before its continuation arrives...
+	if _, err := w.Write([]byte("unrelated line\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	// ...and only then the first stream's continuation.
+	if _, err := w.Write([]byte("alue end")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if closer, ok := w.(interface{ Close() error }); ok {
+		_ = closer.Close()
+	}
+
+	if bytes.Contains(out.Bytes(), []byte("[REDACTED]")) {
+		t.Fatalf("known limitation changed: the split secret was redacted instead of leaking (output: %q) — update the Wrap doc comment and this test together", out.String())
+	}
+	if !bytes.Contains(out.Bytes(), []byte("secretv")) {
+		t.Fatalf("expected the unredacted secret prefix %q to leak into output under this interleaving, got %q", "secretv", out.String())
+	}
+}
+
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
+
+func TestRedactingSlogHandlerRedactsGroupedAttrs(t *testing.T) {
+	r := NewRedactor()
+	r.Add("s3cr3t")
+
+	var out bytes.Buffer
+	base := slog.NewTextHandler(&out, nil)
+	handler := r.WrapSlog(base)
+	logger := slog.New(handler)
+
+	logger.Info("auth", slog.Group("creds", "password", "s3cr3t"))
+
+	if bytes.Contains(out.Bytes(), []byte("s3cr3t")) {
+		t.Errorf("log output contains unredacted secret: %s", out.String())
+	}
+	if !bytes.Contains(out.Bytes(), []byte("[REDACTED]")) {
+		t.Errorf("log output missing [REDACTED] placeholder: %s", out.String())
+	}
+}
+
+func TestRedactingSlogHandlerEnabled(t *testing.T) {
+	r := NewRedactor()
+	base := slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelWarn})
+	handler := r.WrapSlog(base)
+
+	if handler.Enabled(context.Background(), slog.LevelInfo) {
+		t.Errorf("Enabled(Info) = true, want false (base handler is Warn level)")
+	}
+	if !handler.Enabled(context.Background(), slog.LevelWarn) {
+		t.Errorf("Enabled(Warn) = false, want true")
+	}
+}
@@ -4,22 +4,77 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 	"reflect"
+	"sync"
 	"time"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	awssecretsmanager "github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"golang.org/x/sync/errgroup"
 )
 
-// loadGCPSecret fetches a secret from GCP Secret Manager.
+func init() {
+	defaultRegistry.Register("gcp", SecretProviderFunc(func(ctx context.Context, ref string) (string, error) {
+		secretVersionName, err := resolveGCPSecretName(ctx, ref, gcpProjectOverrideFromContext(ctx))
+		if err != nil {
+			return "", err
+		}
+		return loadGCPSecret(ctx, secretVersionName)
+	}))
+	defaultRegistry.Register("aws", SecretProviderFunc(loadAWSSecret))
+	defaultRegistry.Register("env", SecretProviderFunc(func(_ context.Context, ref string) (string, error) {
+		value, ok := os.LookupEnv(ref)
+		if !ok {
+			return "", fmt.Errorf("environment variable %q is not set", ref)
+		}
+		return value, nil
+	}))
+}
+
+// gcpClientOnce and awsClientOnce build their SDK clients lazily on first
+// use and hold them for the lifetime of the process, so a struct with many
+// gcp_secret/aws_secret fields pays for one client handshake rather than
+// one per field.
+var (
+	gcpClientOnce sync.Once
+	gcpClient     *secretmanager.Client
+	gcpClientErr  error
+
+	awsClientOnce sync.Once
+	awsClient     *awssecretsmanager.Client
+	awsClientErr  error
+)
+
+func getGCPClient(ctx context.Context) (*secretmanager.Client, error) {
+	gcpClientOnce.Do(func() {
+		gcpClient, gcpClientErr = secretmanager.NewClient(ctx)
+	})
+	return gcpClient, gcpClientErr
+}
+
+func getAWSClient(ctx context.Context) (*awssecretsmanager.Client, error) {
+	awsClientOnce.Do(func() {
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			awsClientErr = fmt.Errorf("failed to load AWS SDK config: %w", err)
+			return
+		}
+		awsClient = awssecretsmanager.NewFromConfig(awsCfg)
+	})
+	return awsClient, awsClientErr
+}
+
+// loadGCPSecret fetches a secret from GCP Secret Manager. Registered in the
+// default Registry under the "gcp" scheme, and used directly by the legacy
+// gcp_secret tag.
 func loadGCPSecret(ctx context.Context, secretVersionName string) (string, error) {
-	client, err := secretmanager.NewClient(ctx)
+	client, err := getGCPClient(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to create GCP secret manager client: %w", err)
 	}
-	defer client.Close()
 
 	req := &secretmanagerpb.AccessSecretVersionRequest{
 		Name: secretVersionName,
@@ -33,15 +88,15 @@ func loadGCPSecret(ctx context.Context, secretVersionName string) (string, error
 	return string(result.Payload.Data), nil
 }
 
-// loadAWSSecret fetches a secret from AWS Secrets Manager.
+// loadAWSSecret fetches a secret from AWS Secrets Manager. Registered in
+// the default Registry under the "aws" scheme, and used directly by the
+// legacy aws_secret tag.
 func loadAWSSecret(ctx context.Context, secretName string) (string, error) {
-	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	client, err := getAWSClient(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to load AWS SDK config: %w", err)
+		return "", err
 	}
 
-	client := awssecretsmanager.NewFromConfig(cfg)
-
 	input := &awssecretsmanager.GetSecretValueInput{
 		SecretId: &secretName,
 	}
@@ -64,11 +119,32 @@ func loadAWSSecret(ctx context.Context, secretName string) (string, error) {
 	return "", fmt.Errorf("AWS secret %s value is empty or not a string", secretName)
 }
 
-// LoadConfigFromSecrets populates the fields of a struct pointer with values
-// from GCP Secret Manager or AWS Secrets Manager based on struct tags.
-// The `configStructPtr` must be a pointer to a struct.
-// Fields tagged with `gcp_secret` or `aws_secret` must be of type string.
-func LoadConfigFromSecrets(ctx context.Context, configStructPtr interface{}) error {
+// secretField is a struct field whose value should be populated from a
+// secret store, resolved by calling fetch.
+type secretField struct {
+	cacheKey string // dedup/cache key; distinct providers may share a raw ref, so this is scheme-qualified
+	fetch    func(ctx context.Context) (string, error)
+	targets  []reflect.Value // settable string fields sharing this cacheKey
+}
+
+// LoadConfigFromSecrets populates the fields of a struct pointer with
+// values fetched from secret stores. The `configStructPtr` must be a
+// pointer to a struct. Fields may be tagged with:
+//
+//   - `secret:"<scheme>:<ref>"`, dispatched through the Registry to any
+//     provider registered with RegisterProvider. Built-in schemes are
+//     "gcp", "aws", and "env"; see the providers subpackage for Vault,
+//     AWS SSM, OpenBao, and file backends.
+//   - `gcp_secret` or `aws_secret` (legacy tags, equivalent to the "gcp"
+//     and "aws" schemes above).
+//   - `gcp_secret_label:"key=value,..."` to find a GCP secret by label
+//     selector rather than resource name.
+//
+// Tagged fields must be of type string. Secrets are fetched concurrently,
+// bounded by WithConcurrency (default 8), and each unique reference is
+// fetched once even if multiple fields share it. Pass WithCache to reuse
+// resolved values across calls.
+func LoadConfigFromSecrets(ctx context.Context, configStructPtr interface{}, opts ...Option) error {
 	val := reflect.ValueOf(configStructPtr)
 	if val.Kind() != reflect.Ptr || val.IsNil() {
 		return fmt.Errorf("input must be a non-nil pointer to a struct")
@@ -79,7 +155,69 @@ func LoadConfigFromSecrets(ctx context.Context, configStructPtr interface{}) err
 		return fmt.Errorf("input must be a pointer to a struct")
 	}
 
+	options := newLoadOptions(opts)
+	fields, err := collectSecretFields(elem, options)
+	if err != nil {
+		return err
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(options.concurrency)
+
+	for _, sf := range fields {
+		sf := sf
+		g.Go(func() error {
+			if options.cache != nil {
+				if value, ok := options.cache.Get(sf.cacheKey); ok {
+					sf.write(value)
+					return nil
+				}
+			}
+
+			value, err := sf.fetch(gctx)
+			if err != nil {
+				return err
+			}
+
+			if options.cache != nil {
+				options.cache.Set(sf.cacheKey, value)
+			}
+			sf.write(value)
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+func (sf *secretField) write(value string) {
+	defaultRedactor.Add(value)
+	for _, target := range sf.targets {
+		target.SetString(value)
+	}
+}
+
+// collectSecretFields walks the top-level fields of elem and groups them
+// into one secretField per unique cache key, so two fields referencing the
+// same secret are fetched (and cached) only once.
+func collectSecretFields(elem reflect.Value, options *loadOptions) (map[string]*secretField, error) {
 	typ := elem.Type()
+	fields := make(map[string]*secretField)
+
+	add := func(cacheKey string, fieldName string, fieldVal reflect.Value, fetch func(ctx context.Context) (string, error)) error {
+		if fieldVal.Type().Kind() != reflect.String {
+			return fmt.Errorf("field %s must be of type string, got %s", fieldName, fieldVal.Type().Kind())
+		}
+		sf, ok := fields[cacheKey]
+		if !ok {
+			sf = &secretField{cacheKey: cacheKey, fetch: fetch}
+			fields[cacheKey] = sf
+		}
+		sf.targets = append(sf.targets, fieldVal)
+		return nil
+	}
 
 	for i := 0; i < elem.NumField(); i++ {
 		field := typ.Field(i)
@@ -87,43 +225,73 @@ func LoadConfigFromSecrets(ctx context.Context, configStructPtr interface{}) err
 
 		if !fieldVal.CanSet() {
 			// This typically means the field is unexported.
-			// You might want to log this or skip silently.
 			continue
 		}
 
-		// Check for GCP secret tag
+		if reference, ok := field.Tag.Lookup(TagSecret); ok && reference != "" {
+			if err := add("secret:"+reference, field.Name, fieldVal, func(ctx context.Context) (string, error) {
+				ctx = withGCPProjectOverride(ctx, options.gcpProject)
+				value, err := defaultRegistry.Fetch(ctx, reference)
+				if err != nil {
+					return "", fmt.Errorf("failed to load secret for field %s (ref: %s): %w", field.Name, reference, err)
+				}
+				return value, nil
+			}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
 		if gcpSecretPath, ok := field.Tag.Lookup(TagGcpSecret); ok && gcpSecretPath != "" {
-			if field.Type.Kind() != reflect.String {
-				return fmt.Errorf("field %s with tag '%s' must be of type string, got %s", field.Name, TagGcpSecret, field.Type.Kind())
+			if err := add("gcp:"+gcpSecretPath, field.Name, fieldVal, func(ctx context.Context) (string, error) {
+				secretVersionName, err := resolveGCPSecretName(ctx, gcpSecretPath, options.gcpProject)
+				if err != nil {
+					return "", fmt.Errorf("failed to resolve GCP secret for field %s (path: %s): %w", field.Name, gcpSecretPath, err)
+				}
+				value, err := loadGCPSecret(ctx, secretVersionName)
+				if err != nil {
+					return "", fmt.Errorf("failed to load GCP secret for field %s (path: %s): %w", field.Name, gcpSecretPath, err)
+				}
+				return value, nil
+			}); err != nil {
+				return nil, err
 			}
-			secretValue, err := loadGCPSecret(ctx, gcpSecretPath)
-			if err != nil {
-				return fmt.Errorf("failed to load GCP secret for field %s (path: %s): %w", field.Name, gcpSecretPath, err)
+			continue
+		}
+
+		if gcpLabelSelector, ok := field.Tag.Lookup(TagGcpSecretLabel); ok && gcpLabelSelector != "" {
+			if err := add("gcp-label:"+gcpLabelSelector, field.Name, fieldVal, func(ctx context.Context) (string, error) {
+				value, err := loadGCPSecretByLabel(ctx, gcpLabelSelector, options.gcpProject)
+				if err != nil {
+					return "", fmt.Errorf("failed to load GCP secret for field %s (label: %s): %w", field.Name, gcpLabelSelector, err)
+				}
+				return value, nil
+			}); err != nil {
+				return nil, err
 			}
-			fieldVal.SetString(secretValue)
-			continue // Processed this field, move to the next
+			continue
 		}
 
-		// Check for AWS secret tag
 		if awsSecretName, ok := field.Tag.Lookup(TagAwsSecret); ok && awsSecretName != "" {
-			if field.Type.Kind() != reflect.String {
-				return fmt.Errorf("field %s with tag '%s' must be of type string, got %s", field.Name, TagAwsSecret, field.Type.Kind())
+			if err := add("aws:"+awsSecretName, field.Name, fieldVal, func(ctx context.Context) (string, error) {
+				value, err := loadAWSSecret(ctx, awsSecretName)
+				if err != nil {
+					return "", fmt.Errorf("failed to load AWS secret for field %s (name: %s): %w", field.Name, awsSecretName, err)
+				}
+				return value, nil
+			}); err != nil {
+				return nil, err
 			}
-			secretValue, err := loadAWSSecret(ctx, awsSecretName)
-			if err != nil {
-				return fmt.Errorf("failed to load AWS secret for field %s (name: %s): %w", field.Name, awsSecretName, err)
-			}
-			fieldVal.SetString(secretValue)
-			continue // Processed this field, move to the next
+			continue
 		}
 	}
-	return nil
+	return fields, nil
 }
 
 // AppConfig is an example configuration struct.
 // Replace tag values with your actual secret paths/names.
 type AppConfig struct {
-	APIKeyGCP     string `gcp_secret:"projects/your-gcp-project-id/secrets/your-api-key-secret/versions/latest"`
+	APIKeyGCP     string `gcp_secret:"your-api-key-secret"` // short form; resolved against the current GCP project
 	DBPasswordAWS string `aws_secret:"your/db/password_secret_name_or_arn"`
 	APITokenAWS   string `aws_secret:"another/aws/secret"`
 	RegularValue  string // This field will not be populated from secrets
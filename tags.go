@@ -0,0 +1,25 @@
+package cleanenv
+
+// Struct tag names recognized when loading configuration from secret stores.
+const (
+	// TagGcpSecret is the legacy tag for referencing a GCP Secret Manager
+	// secret. Still supported via the built-in "gcp" provider; see TagSecret
+	// for the provider-agnostic replacement.
+	TagGcpSecret = "gcp_secret"
+
+	// TagAwsSecret is the legacy tag for referencing an AWS Secrets Manager
+	// secret by name or ARN. Still supported via the built-in "aws"
+	// provider; see TagSecret for the provider-agnostic replacement.
+	TagAwsSecret = "aws_secret"
+
+	// TagSecret is the provider-agnostic tag dispatched through the
+	// Registry. Its value is a reference of the form
+	// "<scheme>:<path>[#<subfield>]", e.g.
+	// `secret:"vault:secret/data/db#password"`.
+	TagSecret = "secret"
+
+	// TagGcpSecretLabel selects a GCP secret by label selector instead of
+	// resource name, e.g. `gcp_secret_label:"env=prod,role=db-password"`.
+	// Exactly one secret must match the selector.
+	TagGcpSecretLabel = "gcp_secret_label"
+)